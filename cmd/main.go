@@ -2,16 +2,81 @@ package main
 
 import (
 	"flag"
+	"strings"
 
 	"github.com/sheki/articles"
 )
 
 func main() {
 	var notes = flag.String("notes", "notes.txt", "the file with all notes")
-	var baseDir = flag.String("baseDir", "docs", "the base dir to create the site")
+	var postsDir = flag.String("postsDir", "", "a directory of one .md file per article, with frontmatter (overrides -notes if set)")
+	var baseDir = flag.String("baseDir", "docs", "the base dir to create the HTML site")
+	var geminiDir = flag.String("geminiDir", "gemini", "the base dir to create the gemini site, when -format includes gemini")
+	var drafts = flag.Bool("drafts", false, "include posts marked draft: true (postsDir mode only)")
+	var theme = flag.String("theme", "", "a theme directory to load templates/static from (default: built-in theme, html format only)")
+	var perPage = flag.Int("perPage", 0, "articles per homepage page, paginating into page/2.html etc (0: unpaginated, html format only)")
+	var format = flag.String("format", "html", "comma-separated output formats to generate: html, gemini")
+	var siteTitle = flag.String("siteTitle", "Sheki articles of interest", "the title of the site, used in feeds")
+	var baseURL = flag.String("baseURL", "", "the base URL the site is served from, used in feeds")
+	var author = flag.String("author", "", "the site author, used in feeds")
+	var description = flag.String("description", "", "the site description, used in feeds")
+	var watch = flag.Bool("watch", false, "watch for changes, rebuild and serve baseDir with livereload")
+	var addr = flag.String("addr", ":8080", "address to serve baseDir on in -watch mode")
 	flag.Parse()
-	err := articles.Generate(*notes, *baseDir)
-	if err != nil {
+
+	site := articles.SiteConfig{
+		Title:       *siteTitle,
+		BaseURL:     *baseURL,
+		Author:      *author,
+		Description: *description,
+	}
+
+	formats := strings.Split(*format, ",")
+	opts := articles.BuildOptions{ThemeDir: *theme, PerPage: *perPage}
+
+	generate := func() error {
+		for _, f := range formats {
+			switch strings.TrimSpace(f) {
+			case "html":
+				if *postsDir != "" {
+					if err := articles.GenerateFromDirWithOptions(*postsDir, *baseDir, site, *drafts, opts); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := articles.GenerateWithOptions(*notes, *baseDir, site, opts); err != nil {
+					return err
+				}
+			case "gemini":
+				if *postsDir != "" {
+					if err := articles.GenerateFromDirGemini(*postsDir, *geminiDir, site, *drafts); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := articles.GenerateGemini(*notes, *geminiDir, site); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if *watch {
+		watchPaths := []string{*notes}
+		if *postsDir != "" {
+			watchPaths = []string{*postsDir}
+		}
+		if *theme != "" {
+			watchPaths = append(watchPaths, *theme)
+		}
+		if err := articles.WatchAndServe(generate, watchPaths, *baseDir, *addr); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	if err := generate(); err != nil {
 		panic(err)
 	}
 }
@@ -0,0 +1,189 @@
+package articles
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// SiteConfig holds the site-wide metadata made available to feeds and, as
+// .Site, to every page template.
+type SiteConfig struct {
+	Title       string
+	BaseURL     string
+	Author      string
+	Description string
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	Link       atomLink       `xml:"link"`
+	ID         string         `xml:"id"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name   `xml:"feed"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	Title   string     `xml:"title"`
+	Links   []atomLink `xml:"link"`
+	ID      string     `xml:"id"`
+	Updated string     `xml:"updated"`
+	Author  struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	Channel struct {
+		Title       string    `xml:"title"`
+		Link        string    `xml:"link"`
+		Description string    `xml:"description"`
+		Items       []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+func atomFeedFile(base string) string {
+	return path.Join(base, "atom.xml")
+}
+
+func rssFeedFile(base string) string {
+	return path.Join(base, "rss.xml")
+}
+
+func tagAtomFeedFile(base, tag string) string {
+	return path.Join(base, "tag", fmt.Sprintf("%s.atom", tag))
+}
+
+// joinURL joins base and p with a single slash, tolerating a trailing slash
+// already present on base (a natural way to type -baseURL).
+func joinURL(base, p string) string {
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(base, "/"), p)
+}
+
+func permalink(site SiteConfig, article Article) string {
+	return joinURL(site.BaseURL, article.fileName())
+}
+
+func buildAtomFeed(site SiteConfig, selfPath string, articles []Article) atomFeed {
+	feed := atomFeed{
+		Xmlns: "http://www.w3.org/2005/Atom",
+		Title: site.Title,
+		Links: []atomLink{
+			{Href: site.BaseURL, Rel: "alternate"},
+			{Href: joinURL(site.BaseURL, selfPath), Rel: "self"},
+		},
+		ID: joinURL(site.BaseURL, selfPath),
+	}
+	feed.Author.Name = site.Author
+
+	for _, a := range articles {
+		entry := atomEntry{
+			Title:     a.Header,
+			Link:      atomLink{Href: permalink(site, a), Rel: "alternate"},
+			ID:        permalink(site, a),
+			Published: a.Date.Format(time.RFC3339),
+			Updated:   a.Date.Format(time.RFC3339),
+			Content:   atomContent{Type: "html", Body: renderMarkdownHTML(stripLeadingHeading(a.Content))},
+		}
+		for _, tag := range a.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	if len(articles) > 0 {
+		feed.Updated = articles[0].Date.Format(time.RFC3339)
+	}
+	return feed
+}
+
+func buildRSSFeed(site SiteConfig, articles []Article) rssFeed {
+	var feed rssFeed
+	feed.Version = "2.0"
+	feed.Channel.Title = site.Title
+	feed.Channel.Link = site.BaseURL
+	feed.Channel.Description = site.Description
+
+	for _, a := range articles {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       a.Header,
+			Link:        permalink(site, a),
+			GUID:        permalink(site, a),
+			PubDate:     a.Date.Format(time.RFC1123Z),
+			Categories:  a.Tags,
+			Description: renderMarkdownHTML(stripLeadingHeading(a.Content)),
+		})
+	}
+	return feed
+}
+
+func writeXML(filePath string, v interface{}) error {
+	f, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(f)
+	enc.Indent("", "  ")
+	return enc.Encode(v)
+}
+
+func generateFeeds(base string, site SiteConfig, articles []Article) error {
+	if err := writeXML(atomFeedFile(base), buildAtomFeed(site, "atom.xml", articles)); err != nil {
+		return err
+	}
+	if err := writeXML(rssFeedFile(base), buildRSSFeed(site, articles)); err != nil {
+		return err
+	}
+
+	tagMap := make(map[string][]Article)
+	for _, a := range articles {
+		for _, tag := range a.Tags {
+			tagMap[tag] = append(tagMap[tag], a)
+		}
+	}
+	for tag, tagArticles := range tagMap {
+		selfPath := fmt.Sprintf("tag/%s.atom", tag)
+		if err := writeXML(tagAtomFeedFile(base, tag), buildAtomFeed(site, selfPath, tagArticles)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
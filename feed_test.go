@@ -0,0 +1,105 @@
+package articles
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testArticle(t *testing.T, header, content string) Article {
+	t.Helper()
+	return Article{
+		Header:  header,
+		Content: content,
+		Tags:    []string{"go"},
+		Date:    mustDate(t, "2024-01-02"),
+		Index:   1,
+	}
+}
+
+func TestJoinURL(t *testing.T) {
+	cases := []struct {
+		base, p, want string
+	}{
+		{"https://example.com", "1.html", "https://example.com/1.html"},
+		{"https://example.com/", "1.html", "https://example.com/1.html"},
+		{"", "1.html", "/1.html"},
+	}
+
+	for _, c := range cases {
+		if got := joinURL(c.base, c.p); got != c.want {
+			t.Errorf("joinURL(%q, %q) = %q, want %q", c.base, c.p, got, c.want)
+		}
+	}
+}
+
+func TestPermalinkTrimsTrailingSlash(t *testing.T) {
+	site := SiteConfig{BaseURL: "https://example.com/"}
+	a := Article{Index: 1}
+
+	got := permalink(site, a)
+	want := "https://example.com/1.html"
+	if got != want {
+		t.Errorf("permalink = %q, want %q", got, want)
+	}
+}
+
+func TestBuildAtomFeedStripsDuplicateTitle(t *testing.T) {
+	site := SiteConfig{Title: "Site", BaseURL: "https://example.com", Author: "Author"}
+	a := testArticle(t, "Second post", "# Second post\n\nBody.")
+
+	feed := buildAtomFeed(site, "atom.xml", []Article{a})
+	if len(feed.Entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(feed.Entries))
+	}
+
+	body := feed.Entries[0].Content.Body
+	if strings.Contains(body, "Second post") {
+		t.Errorf("entry body still contains the title: %q", body)
+	}
+	if !strings.Contains(body, "Body.") {
+		t.Errorf("entry body missing article text: %q", body)
+	}
+}
+
+func TestBuildAtomFeedSelfLink(t *testing.T) {
+	site := SiteConfig{BaseURL: "https://example.com/"}
+
+	feed := buildAtomFeed(site, "atom.xml", nil)
+	want := "https://example.com/atom.xml"
+	if feed.ID != want {
+		t.Errorf("feed.ID = %q, want %q", feed.ID, want)
+	}
+	if feed.Links[1].Href != want {
+		t.Errorf("self link = %q, want %q", feed.Links[1].Href, want)
+	}
+}
+
+func TestBuildRSSFeedStripsDuplicateTitle(t *testing.T) {
+	site := SiteConfig{Title: "Site", BaseURL: "https://example.com"}
+	a := testArticle(t, "Second post", "# Second post\n\nBody.")
+
+	feed := buildRSSFeed(site, []Article{a})
+	if len(feed.Channel.Items) != 1 {
+		t.Fatalf("got %d items, want 1", len(feed.Channel.Items))
+	}
+
+	desc := feed.Channel.Items[0].Description
+	if strings.Contains(desc, "Second post") {
+		t.Errorf("item description still contains the title: %q", desc)
+	}
+	if !strings.Contains(desc, "Body.") {
+		t.Errorf("item description missing article text: %q", desc)
+	}
+}
+
+func TestBuildAtomFeedUpdatedIsNewestArticle(t *testing.T) {
+	site := SiteConfig{BaseURL: "https://example.com"}
+	newest := Article{Header: "Newest", Index: 1, Date: mustDate(t, "2024-02-01")}
+
+	feed := buildAtomFeed(site, "atom.xml", []Article{newest})
+	want := newest.Date.Format(time.RFC3339)
+	if feed.Updated != want {
+		t.Errorf("feed.Updated = %q, want %q", feed.Updated, want)
+	}
+}
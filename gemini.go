@@ -0,0 +1,128 @@
+package articles
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// geminiRenderer is a Renderer that emits a gemtext (Gemini protocol) tree
+// mirroring the HTML one, for the small-web/Gemini audience.
+type geminiRenderer struct{}
+
+func (geminiRenderer) fileName(a Article) string {
+	return a.fileNameExt("gmi")
+}
+
+func (g geminiRenderer) RenderIndex(base string, site SiteConfig, articles []Article) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", site.Title)
+	for _, a := range articles {
+		fmt.Fprintf(&b, "=> %s %s\n", g.fileName(a), a.Header)
+	}
+	return os.WriteFile(path.Join(base, "index.gmi"), []byte(b.String()), 0644)
+}
+
+func (g geminiRenderer) RenderArticle(base string, site SiteConfig, article Article, prev, next *Article) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", article.Header)
+	b.WriteString(renderGemtext(stripLeadingHeading(article.Content)))
+	fmt.Fprintf(&b, "\n\nDate: %s\n", article.Date.Format("2 Jan 2006"))
+	for _, tag := range article.Tags {
+		fmt.Fprintf(&b, "=> tag/%s.gmi %s\n", tag, tag)
+	}
+	if prev != nil {
+		fmt.Fprintf(&b, "=> %s %s\n", g.fileName(*prev), prev.Header)
+	}
+	if next != nil {
+		fmt.Fprintf(&b, "=> %s %s\n", g.fileName(*next), next.Header)
+	}
+	return os.WriteFile(path.Join(base, g.fileName(article)), []byte(b.String()), 0644)
+}
+
+func (g geminiRenderer) RenderTag(base string, site SiteConfig, tag string, articles []Article) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Tag: %s\n\n", tag)
+	for _, a := range articles {
+		fmt.Fprintf(&b, "=> /%s %s\n", g.fileName(a), a.Header)
+	}
+	return os.WriteFile(path.Join(base, "tag", fmt.Sprintf("%s.gmi", tag)), []byte(b.String()), 0644)
+}
+
+// GenerateGemini renders notePath as a gemtext site into baseDir.
+func GenerateGemini(notePath string, baseDir string, site SiteConfig) error {
+	arr, err := parseFile(notePath)
+	if err != nil {
+		return err
+	}
+	return renderSite(baseDir, geminiRenderer{}, site, sortArticlesByDate(arr))
+}
+
+var mdLinkRe = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+
+// renderGemtext converts a subset of markdown to gemtext: headings and
+// fenced code blocks pass through unchanged, "- "/"* " list items become
+// "* " lines, and inline links are pulled out of each paragraph into
+// "=> url text" lines placed right after it.
+func renderGemtext(markdown string) string {
+	var out []string
+	var links []string
+	inFence := false
+
+	flushLinks := func() {
+		out = append(out, links...)
+		links = nil
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			if !inFence {
+				flushLinks()
+			}
+			out = append(out, line)
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			out = append(out, line)
+			continue
+		}
+
+		if trimmed == "" {
+			flushLinks()
+			out = append(out, "")
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#") {
+			flushLinks()
+			out = append(out, trimmed)
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") {
+			out = append(out, "* "+extractLinks(trimmed[2:], &links))
+			continue
+		}
+
+		out = append(out, extractLinks(trimmed, &links))
+	}
+	flushLinks()
+
+	return strings.Join(out, "\n")
+}
+
+// extractLinks replaces markdown [text](url) links in line with their text
+// and appends a "=> url text" line to links for each one found.
+func extractLinks(line string, links *[]string) string {
+	return mdLinkRe.ReplaceAllStringFunc(line, func(m string) string {
+		sub := mdLinkRe.FindStringSubmatch(m)
+		text, url := sub[1], sub[2]
+		*links = append(*links, fmt.Sprintf("=> %s %s", url, text))
+		return text
+	})
+}
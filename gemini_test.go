@@ -0,0 +1,95 @@
+package articles
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderGemtext(t *testing.T) {
+	cases := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "heading and paragraph",
+			markdown: "# Title\n\nSome text.",
+			want:     "# Title\n\nSome text.",
+		},
+		{
+			name:     "list item becomes bullet",
+			markdown: "- one\n- two",
+			want:     "* one\n* two",
+		},
+		{
+			name:     "fenced code block passes through",
+			markdown: "```\ncode [not](a-link)\n```",
+			want:     "```\ncode [not](a-link)\n```",
+		},
+		{
+			name:     "inline link pulled out after its paragraph",
+			markdown: "See [my site](https://example.com) for more.",
+			want:     "See my site for more.\n=> https://example.com my site",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := renderGemtext(c.markdown)
+			if got != c.want {
+				t.Errorf("renderGemtext(%q) = %q, want %q", c.markdown, got, c.want)
+			}
+		})
+	}
+}
+
+func TestExtractLinks(t *testing.T) {
+	var links []string
+	got := extractLinks("a [text](http://example.com/a) and [another](http://example.com/b)", &links)
+
+	want := "a text and another"
+	if got != want {
+		t.Errorf("extractLinks text = %q, want %q", got, want)
+	}
+
+	wantLinks := []string{
+		"=> http://example.com/a text",
+		"=> http://example.com/b another",
+	}
+	if strings.Join(links, "|") != strings.Join(wantLinks, "|") {
+		t.Errorf("links = %v, want %v", links, wantLinks)
+	}
+}
+
+func TestStripLeadingHeading(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "heading followed by blank line and body",
+			in:   "# First post\n\nBody.",
+			want: "Body.",
+		},
+		{
+			name: "no leading heading left untouched",
+			in:   "Just body text.",
+			want: "Just body text.",
+		},
+		{
+			name: "heading with nothing after it",
+			in:   "# Only a title",
+			want: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := stripLeadingHeading(c.in)
+			if got != c.want {
+				t.Errorf("stripLeadingHeading(%q) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package articles
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"time"
+)
+
+func pageLink(page int) string {
+	if page <= 1 {
+		return "/index.html"
+	}
+	return fmt.Sprintf("/page/%d.html", page)
+}
+
+// paginate splits articles into chunks of at most perPage, in order.
+// perPage <= 0 means unpaginated: one chunk holding everything.
+func paginate(articles []Article, perPage int) [][]Article {
+	if perPage <= 0 || perPage >= len(articles) {
+		return [][]Article{articles}
+	}
+
+	var pages [][]Article
+	for i := 0; i < len(articles); i += perPage {
+		end := i + perPage
+		if end > len(articles) {
+			end = len(articles)
+		}
+		pages = append(pages, articles[i:end])
+	}
+	return pages
+}
+
+// generateIndexPages writes the (optionally paginated) homepage: index.html,
+// then page/2.html, page/3.html, ... for any further pages.
+func generateIndexPages(base string, theme *Theme, site SiteConfig, articles []Article, perPage int) error {
+	pages := paginate(articles, perPage)
+
+	for i, pageArticles := range pages {
+		d := indexData{
+			Site:        site,
+			Description: site.Description,
+			Articles:    prepareForRender(pageArticles),
+			Page:        i + 1,
+			TotalPages:  len(pages),
+		}
+		if i > 0 {
+			d.Title = fmt.Sprintf("Page %d", i+1)
+			d.PrevLink = pageLink(i)
+		}
+		if i+1 < len(pages) {
+			d.NextLink = pageLink(i + 2)
+		}
+
+		outBase, outFile := base, "index.html"
+		if i > 0 {
+			outBase = path.Join(base, "page")
+			if err := os.MkdirAll(outBase, 0755); err != nil {
+				return err
+			}
+			outFile = fmt.Sprintf("%d.html", i+1)
+		}
+
+		if err := theme.render(outBase, "index", outFile, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveMonth is one month's worth of articles inside an archiveYear.
+type archiveMonth struct {
+	Month    string
+	Articles []renderArticle
+}
+
+// archiveYear groups archiveMonths under .Years in archive.html. buildArchive
+// keeps years and months in the order their articles are given, so they are
+// newest first when the articles are sorted by date (as generateSite does).
+type archiveYear struct {
+	Year   int
+	Months []archiveMonth
+}
+
+// tagCount is a tag and how many articles carry it, with a relative font
+// Size for rendering a tag cloud.
+type tagCount struct {
+	Tag   string
+	Count int
+	Size  float64
+}
+
+// archiveData is what .Site, .Years and .Tags resolve to inside archive.html.
+type archiveData struct {
+	Site        SiteConfig
+	Title       string
+	Description string
+	Years       []archiveYear
+	Tags        []tagCount
+}
+
+// tagIndexData is what .Site and .Tags resolve to inside tags.html.
+type tagIndexData struct {
+	Site        SiteConfig
+	Title       string
+	Description string
+	Tags        []tagCount
+}
+
+func buildArchive(articles []Article) []archiveYear {
+	type key struct {
+		year  int
+		month time.Month
+	}
+
+	var order []key
+	grouped := make(map[key][]Article)
+	for _, a := range articles {
+		k := key{a.Date.Year(), a.Date.Month()}
+		if _, ok := grouped[k]; !ok {
+			order = append(order, k)
+		}
+		grouped[k] = append(grouped[k], a)
+	}
+
+	yearIndex := make(map[int]int)
+	var years []archiveYear
+	for _, k := range order {
+		i, ok := yearIndex[k.year]
+		if !ok {
+			years = append(years, archiveYear{Year: k.year})
+			i = len(years) - 1
+			yearIndex[k.year] = i
+		}
+		years[i].Months = append(years[i].Months, archiveMonth{
+			Month:    k.month.String(),
+			Articles: prepareForRender(grouped[k]),
+		})
+	}
+	return years
+}
+
+func buildTagCounts(articles []Article) []tagCount {
+	counts := make(map[string]int)
+	var order []string
+	for _, a := range articles {
+		for _, tag := range a.Tags {
+			if _, ok := counts[tag]; !ok {
+				order = append(order, tag)
+			}
+			counts[tag]++
+		}
+	}
+	sort.Strings(order)
+
+	tags := make([]tagCount, 0, len(order))
+	for _, tag := range order {
+		count := counts[tag]
+		tags = append(tags, tagCount{Tag: tag, Count: count, Size: 1 + float64(count)*0.3})
+	}
+	return tags
+}
+
+// generateArchive writes archive.html: every article grouped by year and
+// month, newest first, with a tag-count sidebar. Themes aren't required to
+// define "archive"; if they don't, this is a no-op.
+func generateArchive(base string, theme *Theme, site SiteConfig, articles []Article) error {
+	if !theme.hasTemplate("archive") {
+		return nil
+	}
+
+	d := archiveData{
+		Site:        site,
+		Title:       "Archive",
+		Description: site.Description,
+		Years:       buildArchive(articles),
+		Tags:        buildTagCounts(articles),
+	}
+	return theme.render(base, "archive", "archive.html", d)
+}
+
+// generateTagIndex writes tags/index.html: a tag cloud sized by article
+// count. Themes aren't required to define "tags"; if they don't, this is a
+// no-op.
+func generateTagIndex(base string, theme *Theme, site SiteConfig, articles []Article) error {
+	if !theme.hasTemplate("tags") {
+		return nil
+	}
+
+	tagsDir := path.Join(base, "tags")
+	if err := os.MkdirAll(tagsDir, 0755); err != nil {
+		return err
+	}
+
+	d := tagIndexData{
+		Site:        site,
+		Title:       "Tags",
+		Description: site.Description,
+		Tags:        buildTagCounts(articles),
+	}
+	return theme.render(tagsDir, "tags", "index.html", d)
+}
@@ -0,0 +1,96 @@
+package articles
+
+import (
+	"testing"
+	"time"
+)
+
+func mustDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestPaginate(t *testing.T) {
+	articles := make([]Article, 5)
+
+	cases := []struct {
+		name    string
+		perPage int
+		want    []int
+	}{
+		{"unpaginated when perPage is zero", 0, []int{5}},
+		{"unpaginated when perPage is negative", -1, []int{5}},
+		{"single page when perPage >= len", 10, []int{5}},
+		{"splits into even pages", 2, []int{2, 2, 1}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pages := paginate(articles, c.perPage)
+			if len(pages) != len(c.want) {
+				t.Fatalf("got %d pages, want %d", len(pages), len(c.want))
+			}
+			for i, p := range pages {
+				if len(p) != c.want[i] {
+					t.Errorf("page %d has %d articles, want %d", i, len(p), c.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildArchiveGroupsByYearAndMonth(t *testing.T) {
+	articles := []Article{
+		{Header: "Jan 2024 A", Date: mustDate(t, "2024-01-05")},
+		{Header: "Jan 2024 B", Date: mustDate(t, "2024-01-20")},
+		{Header: "Feb 2024", Date: mustDate(t, "2024-02-01")},
+		{Header: "Jan 2023", Date: mustDate(t, "2023-01-01")},
+	}
+
+	years := buildArchive(articles)
+	if len(years) != 2 {
+		t.Fatalf("got %d years, want 2", len(years))
+	}
+
+	if years[0].Year != 2024 {
+		t.Errorf("years[0].Year = %d, want 2024", years[0].Year)
+	}
+	if len(years[0].Months) != 2 {
+		t.Fatalf("got %d months in 2024, want 2", len(years[0].Months))
+	}
+	if years[0].Months[0].Month != "January" || len(years[0].Months[0].Articles) != 2 {
+		t.Errorf("January 2024 = %+v, want 2 articles", years[0].Months[0])
+	}
+
+	if years[1].Year != 2023 {
+		t.Errorf("years[1].Year = %d, want 2023", years[1].Year)
+	}
+}
+
+func TestBuildTagCounts(t *testing.T) {
+	articles := []Article{
+		{Tags: []string{"go", "web"}},
+		{Tags: []string{"go"}},
+		{Tags: []string{"web"}},
+	}
+
+	tags := buildTagCounts(articles)
+	if len(tags) != 2 {
+		t.Fatalf("got %d tags, want 2", len(tags))
+	}
+
+	counts := map[string]int{}
+	for _, tc := range tags {
+		counts[tc.Tag] = tc.Count
+	}
+	if counts["go"] != 2 {
+		t.Errorf("go count = %d, want 2", counts["go"])
+	}
+	if counts["web"] != 2 {
+		t.Errorf("web count = %d, want 2", counts["web"])
+	}
+}
@@ -0,0 +1,89 @@
+package articles
+
+import (
+	"html/template"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// watchEnabled is flipped on by WatchAndServe so the generated pages pick
+// up the livereload script. It is left off for normal one-shot builds.
+var watchEnabled bool
+
+const livereloadScript = `<script>
+(function() {
+	var proto = window.location.protocol === "https:" ? "wss:" : "ws:";
+	var sock = new WebSocket(proto + "//" + window.location.host + "/__livereload");
+	sock.onmessage = function(evt) {
+		if (evt.data === "reload") {
+			window.location.reload();
+		} else if (evt.data.indexOf("error:") === 0) {
+			var el = document.getElementById("__livereload_error") || document.createElement("pre");
+			el.id = "__livereload_error";
+			el.style.cssText = "position:fixed;top:0;left:0;right:0;margin:0;padding:1em;background:#fdd;color:#900;white-space:pre-wrap;z-index:9999";
+			el.textContent = evt.data.slice("error:".length);
+			document.body.appendChild(el);
+		}
+	};
+})();
+</script>`
+
+var templFuncs = template.FuncMap{
+	"livereload": func() template.HTML {
+		if !watchEnabled {
+			return ""
+		}
+		return template.HTML(livereloadScript)
+	},
+}
+
+// livereloadHub tracks the browser tabs connected over the /__livereload
+// WebSocket and broadcasts rebuild notifications to them.
+type livereloadHub struct {
+	mu       sync.Mutex
+	clients  map[*websocket.Conn]bool
+	upgrader websocket.Upgrader
+}
+
+func newLivereloadHub() *livereloadHub {
+	return &livereloadHub{clients: make(map[*websocket.Conn]bool)}
+}
+
+func (h *livereloadHub) handler(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("livereload: upgrade failed:", err)
+		return
+	}
+
+	h.mu.Lock()
+	h.clients[conn] = true
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		delete(h.clients, conn)
+		h.mu.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (h *livereloadHub) broadcast(message string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for conn := range h.clients {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+			conn.Close()
+			delete(h.clients, conn)
+		}
+	}
+}
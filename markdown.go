@@ -0,0 +1,30 @@
+package articles
+
+import (
+	"strings"
+
+	"github.com/golang-commonmark/markdown"
+)
+
+// renderMarkdownHTML renders raw markdown (as kept on Article.Content) to
+// HTML for the renderers and feeds that need it.
+func renderMarkdownHTML(raw string) string {
+	md := markdown.New(markdown.HTML(true), markdown.Nofollow(true))
+	return md.RenderToString([]byte(raw))
+}
+
+// stripLeadingHeading removes a leading "# ..." markdown heading line from
+// raw, along with any blank lines right after it. notes.txt-sourced
+// articles keep their original heading line in Content (see parseArticle),
+// so renderers that print an article's title separately must strip it
+// first to avoid printing the title twice.
+func stripLeadingHeading(raw string) string {
+	lines := strings.SplitN(raw, "\n", 2)
+	if len(lines) == 0 || !strings.HasPrefix(strings.TrimSpace(lines[0]), "# ") {
+		return raw
+	}
+	if len(lines) == 1 {
+		return ""
+	}
+	return strings.TrimLeft(lines[1], "\n")
+}
@@ -7,74 +7,45 @@ import (
 	"log"
 	"os"
 	"path"
+	"sort"
 	"strings"
 	"time"
-
-	"github.com/golang-commonmark/markdown"
 )
 
-const homeTempl = `
-<html>
-<head>
-	<title>Sheki articles of interest</title>
-</head>
-<body>
-	<h1>Articles of interest</h1>
-	{{range .}}
-		<p>
-			<a href="{{.Link}}">{{.Header}}</a>
-		</p>
-	{{end}}
-</body>
-</html>`
-
-const pageTempl = `
-<html>
-  <head>
-	  <title>Sheki articles of interest</title>
-	</head>
-	<body>
-    <p>
-	  {{.Content}}
-		</p>
-		<p>Date: {{.StringDate}}</p>
-		<p>
-		{{range .Tags}}
-		  <a href="tag/{{.}}.html">{{.}}</a>
-		{{end}}
-		</p>
-	</body>
-</html>
-`
-
-const tagTempl = `
-<html>
-<head>
-	<title>Sheki articles of interest</title>
-</head>
-<body>
-<h1>Tag: {{.Tag}}</h1>
-	{{range .Articles}}
-		<p>
-			<a href="/{{.Index}}.html">{{.Header}}</a>
-		</p>
-	{{end}}
-</body>
-</html>`
-
 // Represents an article
 type Article struct {
-	Header  string
-	Content string
-	Tags    []string
-	Date    time.Time
-	Index   int
+	Header      string
+	Content     string
+	Tags        []string
+	Date        time.Time
+	Index       int
+	Slug        string
+	Description string
+}
+
+// fileNameExt is the name an article is rendered under for a given output
+// extension: its slug if it has one, otherwise its numeric index.
+func (a Article) fileNameExt(ext string) string {
+	if a.Slug != "" {
+		return fmt.Sprintf("%s.%s", a.Slug, ext)
+	}
+	return fmt.Sprintf("%d.%s", a.Index, ext)
+}
+
+func (a Article) fileName() string {
+	return a.fileNameExt("html")
 }
 
-type pageArticle struct {
-	Content    template.HTML
-	StringDate string
-	Tags       []string
+// pageData is what .Site, .Content, etc. resolve to inside article.html.
+type pageData struct {
+	Site        SiteConfig
+	Title       string
+	Description string
+	Content     template.HTML
+	StringDate  string
+	Tags        []string
+	Prev        *renderArticle
+	Next        *renderArticle
 }
 
 type renderArticle struct {
@@ -82,143 +53,122 @@ type renderArticle struct {
 	Link   string
 }
 
+// indexData is what .Site, .Articles, etc. resolve to inside index.html.
+type indexData struct {
+	Site        SiteConfig
+	Title       string
+	Description string
+	Articles    []renderArticle
+	Page        int
+	TotalPages  int
+	PrevLink    string
+	NextLink    string
+}
+
+// tagData is what .Site, .Tag and .Articles resolve to inside tag.html.
+type tagData struct {
+	Site        SiteConfig
+	Title       string
+	Description string
+	Tag         string
+	Articles    []renderArticle
+}
+
 func prepareForRender(articles []Article) []renderArticle {
 	var res []renderArticle
 	for _, v := range articles {
 		r := renderArticle{
 			Header: v.Header,
-			Link:   fmt.Sprintf("%d.html", v.Index),
+			Link:   v.fileName(),
 		}
 		res = append(res, r)
 	}
 	return res
 }
 
-func indexFile(base string) string {
-	return path.Join(base, "index.html")
-}
-
-func articleFile(base string, index int) string {
-	return path.Join(base, fmt.Sprintf("%d.html", index))
-}
-
-func generateArticlePage(base string, article Article) error {
-	t, err := template.New("article").Parse(pageTempl)
-
-	if err != nil {
-		return err
+func generateArticlePage(base string, theme *Theme, site SiteConfig, article Article, prev, next *renderArticle) error {
+	p := pageData{
+		Site:        site,
+		Title:       article.Header,
+		Description: article.Description,
+		Content:     template.HTML(renderMarkdownHTML(stripLeadingHeading(article.Content))),
+		StringDate:  article.Date.Format("2 Jan 2006"),
+		Tags:        article.Tags,
+		Prev:        prev,
+		Next:        next,
 	}
-	f, err := os.OpenFile(
-		articleFile(base, article.Index),
-		os.O_RDWR|os.O_CREATE,
-		0644,
-	)
-
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	p := pageArticle{
-		Content:    template.HTML(article.Content),
-		StringDate: article.Date.Format("2 Jan 2006"),
-		Tags:       article.Tags,
-	}
-
-	return t.Execute(f, p)
 
+	return theme.render(base, "article", article.fileName(), p)
 }
 
-func generateArticlePages(base string, articles []Article) error {
-	for _, a := range articles {
-		if err := generateArticlePage(base, a); err != nil {
-			return err
-		}
+func generateTagFile(base string, theme *Theme, site SiteConfig, tag string, articles []Article) error {
+	d := tagData{
+		Site:        site,
+		Title:       fmt.Sprintf("Tag: %s", tag),
+		Description: site.Description,
+		Tag:         tag,
+		Articles:    prepareForRender(articles),
 	}
-	return nil
+	return theme.render(path.Join(base, "tag"), "tag", fmt.Sprintf("%s.html", tag), d)
 }
 
-func tagFile(base, tag string) string {
-	return path.Join(base, "tag", fmt.Sprintf("%s.html", tag))
+// BuildOptions bundles the build-wide knobs that aren't part of the site's
+// own metadata: which theme to render with, and how many articles to put
+// on each page of the homepage (0 or negative means unpaginated).
+type BuildOptions struct {
+	ThemeDir string
+	PerPage  int
 }
 
-func generateTagFile(base string, tag string, articles []Article) error {
-	t, err := template.New("tags").Parse(tagTempl)
-	if err != nil {
-		return err
-	}
-	f, err := os.OpenFile(tagFile(base, tag), os.O_RDWR|os.O_CREATE, 0644)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	render := struct {
-		Articles []Article
-		Tag      string
-	}{Articles: articles, Tag: tag}
-	return t.Execute(f, render)
+func Generate(notePath string, baseDir string, site SiteConfig) error {
+	return GenerateWithOptions(notePath, baseDir, site, BuildOptions{})
 }
 
-func generateTags(base string, articles []Article) error {
-	tagDir := path.Join(base, "tag")
-	if _, err := os.Stat(tagDir); os.IsNotExist(err) {
-		mkdirErr := os.Mkdir(tagDir, 0700)
-		if mkdirErr != nil {
-			return mkdirErr
-		}
-	}
-
-	tagMap := make(map[string][]Article)
-	for _, v := range articles {
-		for _, tag := range v.Tags {
-			tagMap[tag] = append(tagMap[tag], v)
-		}
-	}
-
-	f, err := os.OpenFile(indexFile(base), os.O_RDWR|os.O_CREATE, 0644)
+// GenerateWithOptions is Generate with explicit BuildOptions.
+func GenerateWithOptions(notePath string, baseDir string, site SiteConfig, opts BuildOptions) error {
+	arr, err := parseFile(notePath)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	for k, v := range tagMap {
-		if err := generateTagFile(base, k, v); err != nil {
-			return err
-		}
-	}
-	return nil
+	return generateSite(baseDir, site, opts, sortArticlesByDate(arr))
+}
 
+// sortArticlesByDate returns articles sorted newest first, leaving the
+// input slice untouched.
+func sortArticlesByDate(articles []Article) []Article {
+	sorted := make([]Article, len(articles))
+	copy(sorted, articles)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.After(sorted[j].Date)
+	})
+	return sorted
 }
 
-func generateIndex(base string, articles []Article) error {
-	t, err := template.New("webpage").Parse(homeTempl)
+func generateSite(baseDir string, site SiteConfig, opts BuildOptions, arr []Article) error {
+	theme, err := loadTheme(opts.ThemeDir)
 	if err != nil {
 		return err
 	}
-	f, err := os.OpenFile(indexFile(base), os.O_RDWR|os.O_CREATE, 0644)
-	if err != nil {
+
+	renderer := htmlRenderer{theme: theme, perPage: opts.PerPage}
+	if err := renderSite(baseDir, renderer, site, arr); err != nil {
 		return err
 	}
-	defer f.Close()
-	return t.Execute(f, prepareForRender(articles))
-}
 
-func Generate(notePath string, baseDir string) error {
-	arr, err := parseFile(notePath)
-	if err != nil {
+	if err := generateArchive(baseDir, theme, site, arr); err != nil {
 		return err
 	}
 
-	if err := generateIndex(baseDir, arr); err != nil {
+	if err := generateTagIndex(baseDir, theme, site, arr); err != nil {
 		return err
 	}
 
-	if err := generateArticlePages(baseDir, arr); err != nil {
+	if err := theme.copyStatic(baseDir); err != nil {
 		return err
 	}
 
-	return generateTags(baseDir, arr)
-
+	return generateFeeds(baseDir, site, arr)
 }
 
 // Parses a File into various articles
@@ -267,15 +217,7 @@ func parseFile(path string) ([]Article, error) {
 		res = append(res, a)
 
 	}
-	return reverse(res), nil
-}
-
-func reverse(res []Article) []Article {
-	n := make([]Article, len(res))
-	for i := len(res) - 1; i >= 0; i-- {
-		n = append(n, res[i])
-	}
-	return n
+	return res, nil
 }
 
 func parseArticle(header string, lines []string) (Article, error) {
@@ -316,7 +258,6 @@ func parseArticle(header string, lines []string) (Article, error) {
 		a.Tags = append(a.Tags, strings.TrimSpace(tag))
 	}
 
-	md := markdown.New(markdown.HTML(true), markdown.Nofollow(true))
-	a.Content = md.RenderToString([]byte(strings.Join(markDown, "\n")))
+	a.Content = strings.Join(markDown, "\n")
 	return a, nil
 }
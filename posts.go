@@ -0,0 +1,107 @@
+package articles
+
+import (
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/adrg/frontmatter"
+)
+
+// postMatter is the YAML/TOML frontmatter understood by GenerateFromDir.
+type postMatter struct {
+	Title       string   `yaml:"title" toml:"title"`
+	Date        string   `yaml:"date" toml:"date"`
+	Tags        []string `yaml:"tags" toml:"tags"`
+	Draft       bool     `yaml:"draft" toml:"draft"`
+	Slug        string   `yaml:"slug" toml:"slug"`
+	Description string   `yaml:"description" toml:"description"`
+}
+
+// GenerateFromDir ingests one article per .md file under postsDir instead of
+// a single notes.txt. Each file starts with YAML or TOML frontmatter
+// followed by its markdown body. Drafts are skipped unless allowDrafts is
+// set.
+func GenerateFromDir(postsDir string, baseDir string, site SiteConfig, allowDrafts bool) error {
+	return GenerateFromDirWithOptions(postsDir, baseDir, site, allowDrafts, BuildOptions{})
+}
+
+// GenerateFromDirWithOptions is GenerateFromDir with explicit BuildOptions.
+func GenerateFromDirWithOptions(postsDir string, baseDir string, site SiteConfig, allowDrafts bool, opts BuildOptions) error {
+	arr, err := parsePostsDir(postsDir, allowDrafts)
+	if err != nil {
+		return err
+	}
+
+	return generateSite(baseDir, site, opts, sortArticlesByDate(arr))
+}
+
+// GenerateFromDirGemini is the postsDir equivalent of GenerateGemini.
+func GenerateFromDirGemini(postsDir string, baseDir string, site SiteConfig, allowDrafts bool) error {
+	arr, err := parsePostsDir(postsDir, allowDrafts)
+	if err != nil {
+		return err
+	}
+
+	return renderSite(baseDir, geminiRenderer{}, site, sortArticlesByDate(arr))
+}
+
+func parsePostsDir(postsDir string, allowDrafts bool) ([]Article, error) {
+	entries, err := os.ReadDir(postsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Article
+	index := 1
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+
+		a, draft, err := parsePostFile(path.Join(postsDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if draft && !allowDrafts {
+			continue
+		}
+
+		a.Index = index
+		index++
+		res = append(res, a)
+	}
+	return res, nil
+}
+
+func parsePostFile(filePath string) (Article, bool, error) {
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return Article{}, false, err
+	}
+
+	var matter postMatter
+	body, err := frontmatter.Parse(strings.NewReader(string(raw)), &matter)
+	if err != nil {
+		return Article{}, false, err
+	}
+
+	a := Article{
+		Header:      matter.Title,
+		Tags:        matter.Tags,
+		Slug:        matter.Slug,
+		Description: matter.Description,
+	}
+
+	if matter.Date != "" {
+		a.Date, err = time.Parse("2006-01-02", matter.Date)
+		if err != nil {
+			return Article{}, false, err
+		}
+	}
+
+	a.Content = string(body)
+
+	return a, matter.Draft, nil
+}
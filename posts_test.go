@@ -0,0 +1,122 @@
+package articles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePostFile(t *testing.T) {
+	cases := []struct {
+		name        string
+		content     string
+		wantHeader  string
+		wantDate    string
+		wantTags    []string
+		wantSlug    string
+		wantDraft   bool
+		wantContent string
+	}{
+		{
+			name: "full frontmatter",
+			content: `---
+title: First post
+date: 2024-01-02
+tags: [go, web]
+slug: first-post
+description: An intro post
+draft: false
+---
+Hello, world.
+`,
+			wantHeader:  "First post",
+			wantDate:    "2024-01-02",
+			wantTags:    []string{"go", "web"},
+			wantSlug:    "first-post",
+			wantDraft:   false,
+			wantContent: "Hello, world.\n",
+		},
+		{
+			name: "draft post",
+			content: `---
+title: Unfinished
+date: 2024-03-04
+draft: true
+---
+Still writing.
+`,
+			wantHeader:  "Unfinished",
+			wantDate:    "2024-03-04",
+			wantDraft:   true,
+			wantContent: "Still writing.\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dir := t.TempDir()
+			p := filepath.Join(dir, "post.md")
+			if err := os.WriteFile(p, []byte(c.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			a, draft, err := parsePostFile(p)
+			if err != nil {
+				t.Fatalf("parsePostFile: %v", err)
+			}
+			if a.Header != c.wantHeader {
+				t.Errorf("Header = %q, want %q", a.Header, c.wantHeader)
+			}
+			if a.Date.Format("2006-01-02") != c.wantDate {
+				t.Errorf("Date = %q, want %q", a.Date.Format("2006-01-02"), c.wantDate)
+			}
+			if a.Slug != c.wantSlug {
+				t.Errorf("Slug = %q, want %q", a.Slug, c.wantSlug)
+			}
+			if draft != c.wantDraft {
+				t.Errorf("draft = %v, want %v", draft, c.wantDraft)
+			}
+			if a.Content != c.wantContent {
+				t.Errorf("Content = %q, want %q", a.Content, c.wantContent)
+			}
+			if len(a.Tags) != len(c.wantTags) {
+				t.Fatalf("Tags = %v, want %v", a.Tags, c.wantTags)
+			}
+			for i, tag := range c.wantTags {
+				if a.Tags[i] != tag {
+					t.Errorf("Tags[%d] = %q, want %q", i, a.Tags[i], tag)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePostsDirFiltersDrafts(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.md":  "---\ntitle: A\ndate: 2024-01-01\n---\nbody\n",
+		"b.md":  "---\ntitle: B\ndate: 2024-01-02\ndraft: true\n---\nbody\n",
+		"c.txt": "not a post",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	articles, err := parsePostsDir(dir, false)
+	if err != nil {
+		t.Fatalf("parsePostsDir: %v", err)
+	}
+	if len(articles) != 1 || articles[0].Header != "A" {
+		t.Fatalf("with drafts excluded, got %+v", articles)
+	}
+
+	articles, err = parsePostsDir(dir, true)
+	if err != nil {
+		t.Fatalf("parsePostsDir: %v", err)
+	}
+	if len(articles) != 2 {
+		t.Fatalf("with drafts included, got %+v", articles)
+	}
+}
@@ -0,0 +1,82 @@
+package articles
+
+import (
+	"os"
+	"path"
+)
+
+// Renderer turns parsed articles into a tree of output files. The HTML
+// pipeline (htmlRenderer, theme-based) is the default; other renderers
+// implement the same shape so multiple output trees can be produced from a
+// single parse of the source articles.
+type Renderer interface {
+	RenderIndex(base string, site SiteConfig, articles []Article) error
+	RenderArticle(base string, site SiteConfig, article Article, prev, next *Article) error
+	RenderTag(base string, site SiteConfig, tag string, articles []Article) error
+}
+
+type htmlRenderer struct {
+	theme   *Theme
+	perPage int
+}
+
+func (r htmlRenderer) RenderIndex(base string, site SiteConfig, articles []Article) error {
+	return generateIndexPages(base, r.theme, site, articles, r.perPage)
+}
+
+func (r htmlRenderer) RenderArticle(base string, site SiteConfig, article Article, prev, next *Article) error {
+	return generateArticlePage(base, r.theme, site, article, renderArticlePtr(prev), renderArticlePtr(next))
+}
+
+func (r htmlRenderer) RenderTag(base string, site SiteConfig, tag string, articles []Article) error {
+	return generateTagFile(base, r.theme, site, tag, articles)
+}
+
+func renderArticlePtr(a *Article) *renderArticle {
+	if a == nil {
+		return nil
+	}
+	r := prepareForRender([]Article{*a})[0]
+	return &r
+}
+
+// renderSite walks articles through renderer, writing the index, every
+// article page and every tag page into base.
+func renderSite(base string, renderer Renderer, site SiteConfig, articles []Article) error {
+	if err := renderer.RenderIndex(base, site, articles); err != nil {
+		return err
+	}
+
+	for i, a := range articles {
+		var prev, next *Article
+		if i > 0 {
+			prev = &articles[i-1]
+		}
+		if i < len(articles)-1 {
+			next = &articles[i+1]
+		}
+		if err := renderer.RenderArticle(base, site, a, prev, next); err != nil {
+			return err
+		}
+	}
+
+	tagDir := path.Join(base, "tag")
+	if _, err := os.Stat(tagDir); os.IsNotExist(err) {
+		if err := os.Mkdir(tagDir, 0700); err != nil {
+			return err
+		}
+	}
+
+	tagMap := make(map[string][]Article)
+	for _, a := range articles {
+		for _, tag := range a.Tags {
+			tagMap[tag] = append(tagMap[tag], a)
+		}
+	}
+	for tag, tagArticles := range tagMap {
+		if err := renderer.RenderTag(base, site, tag, tagArticles); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package articles
+
+import (
+	"embed"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+)
+
+//go:embed themes/default
+var defaultThemeFS embed.FS
+
+const defaultThemeRoot = "themes/default"
+
+// Theme is a set of page templates plus the static assets they reference.
+// Generate loads the built-in default theme unless a -theme dir is given,
+// in which case it is loaded from disk instead. A theme only covers HTML
+// pages (index/article/tag, plus archive/tags if it defines them); feeds
+// are always generated by feed.go's fixed encoding/xml pipeline and are
+// never theme-templated.
+type Theme struct {
+	templates *template.Template
+	static    fs.FS
+}
+
+func loadTheme(dir string) (*Theme, error) {
+	if dir == "" {
+		return loadThemeFS(defaultThemeFS, defaultThemeRoot)
+	}
+	return loadThemeFS(os.DirFS(dir), ".")
+}
+
+func loadThemeFS(fsys fs.FS, root string) (*Theme, error) {
+	sub, err := fs.Sub(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := template.New("theme").Funcs(templFuncs).ParseFS(sub, "*.html")
+	if err != nil {
+		return nil, err
+	}
+
+	var static fs.FS
+	if info, err := fs.Stat(sub, "static"); err == nil && info.IsDir() {
+		static, err = fs.Sub(sub, "static")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Theme{templates: t, static: static}, nil
+}
+
+func (th *Theme) render(base, name, outFile string, data interface{}) error {
+	f, err := os.OpenFile(path.Join(base, outFile), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return th.templates.ExecuteTemplate(f, name, data)
+}
+
+// hasTemplate reports whether the theme defines a template named name, for
+// callers that render optional pages (e.g. archive.html, tags.html) which
+// a minimal theme isn't required to provide.
+func (th *Theme) hasTemplate(name string) bool {
+	return th.templates.Lookup(name) != nil
+}
+
+// copyStatic copies the theme's static/ directory (if any) verbatim into base.
+func (th *Theme) copyStatic(base string) error {
+	if th.static == nil {
+		return nil
+	}
+
+	dest := path.Join(base, "static")
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+
+	return fs.WalkDir(th.static, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == "." {
+			return nil
+		}
+
+		out := path.Join(dest, p)
+		if d.IsDir() {
+			return os.MkdirAll(out, 0755)
+		}
+
+		src, err := th.static.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		w, err := os.OpenFile(out, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+
+		_, err = io.Copy(w, src)
+		return err
+	})
+}
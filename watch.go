@@ -0,0 +1,101 @@
+package articles
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const watchDebounce = 100 * time.Millisecond
+
+// WatchAndServe runs generate once, then re-runs it every time one of
+// watchPaths changes on disk, serving baseDir over HTTP on addr in the
+// meantime. Browser tabs left open on the site are told to reload over a
+// WebSocket as soon as a rebuild finishes; build errors are reported both
+// to the terminal and to those tabs.
+func WatchAndServe(generate func() error, watchPaths []string, baseDir string, addr string) error {
+	watchEnabled = true
+
+	if err := generate(); err != nil {
+		log.Println("build error:", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	for _, p := range watchPaths {
+		if err := addWatchRecursive(watcher, p); err != nil {
+			return err
+		}
+	}
+
+	hub := newLivereloadHub()
+	go watchLoop(watcher, hub, generate)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/__livereload", hub.handler)
+	mux.Handle("/", http.FileServer(http.Dir(baseDir)))
+
+	log.Printf("serving %s on %s", baseDir, addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+func watchLoop(watcher *fsnotify.Watcher, hub *livereloadHub, generate func() error) {
+	var timer *time.Timer
+	rebuild := func() {
+		if err := generate(); err != nil {
+			log.Println("build error:", err)
+			hub.broadcast("error:" + err.Error())
+			return
+		}
+		hub.broadcast("reload")
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(watchDebounce, rebuild)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Println("watch error:", err)
+		}
+	}
+}
+
+func addWatchRecursive(watcher *fsnotify.Watcher, root string) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return watcher.Add(root)
+	}
+
+	return filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return watcher.Add(p)
+		}
+		return nil
+	})
+}